@@ -0,0 +1,65 @@
+package main
+
+import (
+    "bufio"
+    "fmt"
+    "os"
+    "path/filepath"
+    "regexp"
+    "strings"
+)
+
+type cargoParser struct{}
+
+func (cargoParser) Detect(path string) bool {
+    return fileExists(filepath.Join(path, "Cargo.lock"))
+}
+
+func (cargoParser) Parse(path string) ([]string, error) {
+    return parseCargoLock(filepath.Join(path, "Cargo.lock"))
+}
+
+var (
+    cargoNamePattern    = regexp.MustCompile(`^name\s*=\s*"([^"]+)"`)
+    cargoVersionPattern = regexp.MustCompile(`^version\s*=\s*"([^"]+)"`)
+)
+
+// parseCargoLock reads the [[package]] blocks of a Cargo.lock TOML file,
+// the same line-oriented way parsePoetryLock reads poetry.lock.
+func parseCargoLock(path string) ([]string, error) {
+    f, err := os.Open(path)
+    if err != nil {
+        return nil, err
+    }
+    defer f.Close()
+
+    var pkgs []string
+    var name, version string
+    blockLine := 0
+    scanner := bufio.NewScanner(f)
+    flush := func() {
+        if name != "" && version != "" {
+            coord := fmt.Sprintf("pkg:cargo/%s@%s", name, version)
+            recordLocation(coord, path, blockLine)
+            pkgs = append(pkgs, coord)
+        }
+        name, version = "", ""
+    }
+    lineNum := 0
+    for scanner.Scan() {
+        lineNum++
+        line := strings.TrimSpace(scanner.Text())
+        if line == "[[package]]" {
+            flush()
+            blockLine = lineNum
+            continue
+        }
+        if m := cargoNamePattern.FindStringSubmatch(line); m != nil {
+            name = m[1]
+        } else if m := cargoVersionPattern.FindStringSubmatch(line); m != nil {
+            version = m[1]
+        }
+    }
+    flush()
+    return pkgs, scanner.Err()
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+    "fmt"
+    "math"
+    "strconv"
+    "strings"
+)
+
+// The cvss31* tables map each CVSS v3.1 base metric to its defined weight per
+// possible value (https://www.first.org/cvss/v3-1/specification-document,
+// section 7.4).
+var cvss31AttackVector = map[string]float64{"N": 0.85, "A": 0.62, "L": 0.55, "P": 0.2}
+var cvss31AttackComplexity = map[string]float64{"L": 0.77, "H": 0.44}
+var cvss31UserInteraction = map[string]float64{"N": 0.85, "R": 0.62}
+var cvss31Impact = map[string]float64{"N": 0, "L": 0.22, "H": 0.56}
+var cvss31PrivilegesRequiredUnchanged = map[string]float64{"N": 0.85, "L": 0.62, "H": 0.27}
+var cvss31PrivilegesRequiredChanged = map[string]float64{"N": 0.85, "L": 0.68, "H": 0.5}
+
+// parseCVSSVector computes a CVSS v3.1 base score from its vector string
+// (e.g. "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H"), following the
+// base score formula in the CVSS v3.1 specification.
+func parseCVSSVector(vector string) (float64, error) {
+    metrics := make(map[string]string)
+    for _, part := range strings.Split(vector, "/") {
+        kv := strings.SplitN(part, ":", 2)
+        if len(kv) == 2 {
+            metrics[kv[0]] = kv[1]
+        }
+    }
+
+    av, ok := cvss31AttackVector[metrics["AV"]]
+    if !ok {
+        return 0, fmt.Errorf("unrecognized or missing AV metric in %q", vector)
+    }
+    ac, ok := cvss31AttackComplexity[metrics["AC"]]
+    if !ok {
+        return 0, fmt.Errorf("unrecognized or missing AC metric in %q", vector)
+    }
+    ui, ok := cvss31UserInteraction[metrics["UI"]]
+    if !ok {
+        return 0, fmt.Errorf("unrecognized or missing UI metric in %q", vector)
+    }
+    conf, ok := cvss31Impact[metrics["C"]]
+    if !ok {
+        return 0, fmt.Errorf("unrecognized or missing C metric in %q", vector)
+    }
+    integ, ok := cvss31Impact[metrics["I"]]
+    if !ok {
+        return 0, fmt.Errorf("unrecognized or missing I metric in %q", vector)
+    }
+    avail, ok := cvss31Impact[metrics["A"]]
+    if !ok {
+        return 0, fmt.Errorf("unrecognized or missing A metric in %q", vector)
+    }
+
+    scopeChanged := metrics["S"] == "C"
+    prTable := cvss31PrivilegesRequiredUnchanged
+    if scopeChanged {
+        prTable = cvss31PrivilegesRequiredChanged
+    }
+    pr, ok := prTable[metrics["PR"]]
+    if !ok {
+        return 0, fmt.Errorf("unrecognized or missing PR metric in %q", vector)
+    }
+
+    iss := 1 - (1-conf)*(1-integ)*(1-avail)
+    var impact float64
+    if scopeChanged {
+        impact = 7.52*(iss-0.029) - 3.25*math.Pow(iss-0.02, 15)
+    } else {
+        impact = 6.42 * iss
+    }
+    if impact <= 0 {
+        return 0, nil
+    }
+
+    exploitability := 8.22 * av * ac * pr * ui
+
+    var base float64
+    if scopeChanged {
+        base = roundUpToOneDecimal(math.Min(1.08*(impact+exploitability), 10))
+    } else {
+        base = roundUpToOneDecimal(math.Min(impact+exploitability, 10))
+    }
+    return base, nil
+}
+
+// roundUpToOneDecimal implements CVSS's specified "round up" behavior,
+// which is not the same as standard rounding: e.g. 4.02 rounds up to 4.1.
+func roundUpToOneDecimal(x float64) float64 {
+    intInput := int64(math.Round(x * 100000))
+    if intInput%10000 == 0 {
+        return float64(intInput) / 100000
+    }
+    return float64(intInput/10000+1) / 10
+}
+
+// parseCVSSVectorScore extracts a base score from an OSV severity score
+// field, which is usually a CVSS vector string (e.g. from vuln.go.dev) but
+// is sometimes a bare numeric score.
+func parseCVSSVectorScore(score string) (float64, error) {
+    if f, err := strconv.ParseFloat(score, 64); err == nil {
+        return f, nil
+    }
+    if strings.HasPrefix(score, "CVSS:3.1/") || strings.HasPrefix(score, "CVSS:3.0/") {
+        return parseCVSSVector(score)
+    }
+    return 0, fmt.Errorf("unrecognized CVSS score format: %q", score)
+}
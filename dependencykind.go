@@ -0,0 +1,33 @@
+package main
+
+// directDependencies records whether each dependency coordinate was
+// declared directly by the project or pulled in transitively, as
+// determined while parsing go.mod/go.sum or resolving the effective POM.
+// Populated and read from a single goroutine per run, same as
+// sourceLocations.
+var directDependencies = map[string]bool{}
+
+func recordDependencyKind(coord string, direct bool) {
+    directDependencies[coord] = direct
+}
+
+// isDirectDependency reports coord's direct/transitive classification, and
+// whether one is known at all (ecosystems without dependency-graph
+// resolution never record one).
+func isDirectDependency(coord string) (direct bool, known bool) {
+    direct, known = directDependencies[coord]
+    return direct, known
+}
+
+// dependencyKindLabel renders a coordinate's direct/transitive
+// classification for display, or "" when unknown.
+func dependencyKindLabel(coord string) string {
+    direct, known := isDirectDependency(coord)
+    if !known {
+        return ""
+    }
+    if direct {
+        return "direct"
+    }
+    return "transitive"
+}
@@ -0,0 +1,202 @@
+package main
+
+import (
+    "encoding/json"
+    "fmt"
+    "io"
+    "strings"
+)
+
+// jsonReportFinding is one row of the --format=json schema: a single
+// vulnerability against a single dependency, flattened for easy consumption
+// by downstream tooling.
+type jsonReportFinding struct {
+    Dependency      string  `json:"dependency"`
+    DependencyType  string  `json:"dependencyType,omitempty"` // "direct" or "transitive", when known
+    VulnerabilityID string  `json:"vulnerabilityId"`
+    CVE             string  `json:"cve,omitempty"`
+    Title           string  `json:"title"`
+    Severity        float64 `json:"severity"`
+    Fix             string  `json:"fix"`
+}
+
+func toJSONFindings(results []OSSIndexResponse) []jsonReportFinding {
+    var findings []jsonReportFinding
+    for _, r := range results {
+        for _, v := range r.Vulnerabilities {
+            fix := extractUpgradeSuggestion(v.Description)
+            if fix == "" {
+                fix = "Check latest version at reference URL."
+            }
+            findings = append(findings, jsonReportFinding{
+                Dependency:      r.Coordinates,
+                DependencyType:  dependencyKindLabel(r.Coordinates),
+                VulnerabilityID: v.ID,
+                CVE:             v.CVE,
+                Title:           v.Title,
+                Severity:        v.CVSSScore,
+                Fix:             fix,
+            })
+        }
+    }
+    return findings
+}
+
+// renderJSONReport writes the --format=json report to w.
+func renderJSONReport(w io.Writer, results []OSSIndexResponse) error {
+    enc := json.NewEncoder(w)
+    enc.SetIndent("", "  ")
+    return enc.Encode(toJSONFindings(results))
+}
+
+// SARIF 2.1.0 (https://docs.oasis-open.org/sarif/sarif/v2.1.0) structures,
+// limited to the fields the scanner populates.
+type sarifLog struct {
+    Schema  string     `json:"$schema"`
+    Version string     `json:"version"`
+    Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+    Tool    sarifTool    `json:"tool"`
+    Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+    Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+    Name    string `json:"name"`
+    Version string `json:"version"`
+}
+
+type sarifResult struct {
+    RuleID    string              `json:"ruleId"`
+    Level     string              `json:"level"`
+    Message   sarifMessage        `json:"message"`
+    Locations []sarifResultLocation `json:"locations"`
+}
+
+type sarifMessage struct {
+    Text string `json:"text"`
+}
+
+type sarifResultLocation struct {
+    PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+    ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+    Region           sarifRegion           `json:"region"`
+}
+
+type sarifArtifactLocation struct {
+    URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+    StartLine int `json:"startLine"`
+}
+
+// sarifLevel maps a CVSS score to a SARIF result level.
+func sarifLevel(score float64) string {
+    switch {
+    case score >= 7:
+        return "error"
+    case score >= 4:
+        return "warning"
+    default:
+        return "note"
+    }
+}
+
+// renderSARIFReport writes each vulnerability as a SARIF result, located at
+// the manifest file and line number recovered while parsing, when known.
+func renderSARIFReport(w io.Writer, results []OSSIndexResponse) error {
+    log := sarifLog{
+        Schema:  "https://raw.githubusercontent.com/oasis-tcs/sarif-spec/master/Schemata/sarif-schema-2.1.0.json",
+        Version: "2.1.0",
+        Runs: []sarifRun{{
+            Tool: sarifTool{Driver: sarifDriver{Name: "vulnscanner", Version: "dev"}},
+        }},
+    }
+
+    for _, r := range results {
+        loc, hasLoc := lookupLocation(r.Coordinates)
+        kind := dependencyKindLabel(r.Coordinates)
+        for _, v := range r.Vulnerabilities {
+            text := fmt.Sprintf("%s (%s)", v.Title, r.Coordinates)
+            if kind != "" {
+                text = fmt.Sprintf("%s [%s]", text, kind)
+            }
+            result := sarifResult{
+                RuleID:  firstNonEmpty(v.CVE, v.ID),
+                Level:   sarifLevel(v.CVSSScore),
+                Message: sarifMessage{Text: text},
+            }
+            if hasLoc {
+                result.Locations = []sarifResultLocation{{
+                    PhysicalLocation: sarifPhysicalLocation{
+                        ArtifactLocation: sarifArtifactLocation{URI: loc.File},
+                        Region:           sarifRegion{StartLine: loc.Line},
+                    },
+                }}
+            }
+            log.Runs[0].Results = append(log.Runs[0].Results, result)
+        }
+    }
+
+    enc := json.NewEncoder(w)
+    enc.SetIndent("", "  ")
+    return enc.Encode(log)
+}
+
+// severityRank orders the --fail-on severity names so a finding's CVSS
+// score can be compared against a threshold.
+func severityRank(name string) (int, error) {
+    switch strings.ToLower(name) {
+    case "low":
+        return 1, nil
+    case "medium":
+        return 2, nil
+    case "high":
+        return 3, nil
+    case "critical":
+        return 4, nil
+    default:
+        return 0, fmt.Errorf("unknown severity %q (want low, medium, high, or critical)", name)
+    }
+}
+
+// scoreRank buckets a CVSS score into the same low/medium/high/critical
+// tiers severityColor and severityLabel already use.
+func scoreRank(score float64) int {
+    switch {
+    case score >= 9:
+        return 4
+    case score >= 7:
+        return 3
+    case score >= 4:
+        return 2
+    default:
+        return 1
+    }
+}
+
+// meetsFailOnThreshold reports whether any finding in results is at or
+// above the --fail-on severity, so CI pipelines can gate on it.
+func meetsFailOnThreshold(results []OSSIndexResponse, failOn string) (bool, error) {
+    threshold, err := severityRank(failOn)
+    if err != nil {
+        return false, err
+    }
+    for _, r := range results {
+        for _, v := range r.Vulnerabilities {
+            if scoreRank(v.CVSSScore) >= threshold {
+                return true, nil
+            }
+        }
+    }
+    return false, nil
+}
@@ -0,0 +1,231 @@
+package main
+
+import (
+    "bufio"
+    "bytes"
+    "encoding/json"
+    "fmt"
+    "os"
+    "os/exec"
+    "path/filepath"
+    "regexp"
+    "strings"
+)
+
+// goListModule is the subset of `go list -m -json`'s per-module object the
+// scanner needs. Indirect is Go's own direct/transitive classification, so
+// it's reused here rather than re-deriving it.
+type goListModule struct {
+    Path     string
+    Version  string
+    Main     bool
+    Indirect bool
+}
+
+// parseGoMod enumerates the full resolved module graph for the project at
+// goModPath, preferring `go list -m -json all` (which also reports
+// replace directives and the real resolved versions) and falling back to
+// go.sum when the module cache or network is unavailable.
+func parseGoMod(goModPath string) ([]string, error) {
+    dir := filepath.Dir(goModPath)
+
+    pkgs, err := parseGoModViaGoList(dir)
+    if err == nil {
+        return pkgs, nil
+    }
+
+    goSumPath := filepath.Join(dir, "go.sum")
+    if _, statErr := os.Stat(goSumPath); statErr == nil {
+        return parseGoModViaGoSum(goModPath, goSumPath)
+    }
+    return parseGoModRequireLines(goModPath)
+}
+
+func parseGoModViaGoList(dir string) ([]string, error) {
+    cmd := exec.Command("go", "list", "-m", "-json", "all")
+    cmd.Dir = dir
+    out, err := cmd.Output()
+    if err != nil {
+        return nil, fmt.Errorf("go list -m -json all: %w", err)
+    }
+
+    goModPath := filepath.Join(dir, "go.mod")
+    goSumPath := filepath.Join(dir, "go.sum")
+    // Best effort: parseGoModRequireSet records a location for every direct
+    // require as a side effect, and go.sum (when present) pins the exact
+    // line for everything else, so SARIF output can still point at the
+    // manifest even though `go list` itself doesn't report line numbers.
+    _, _ = parseGoModRequireSet(goModPath)
+    sumLines, _ := parseGoSumLineNumbers(goSumPath)
+
+    var pkgs []string
+    dec := json.NewDecoder(bytes.NewReader(out))
+    for dec.More() {
+        var m goListModule
+        if err := dec.Decode(&m); err != nil {
+            return nil, fmt.Errorf("decoding go list output: %w", err)
+        }
+        if m.Main || m.Version == "" {
+            continue // the project's own module has no version to scan
+        }
+        coord := fmt.Sprintf("pkg:golang/%s@%s", m.Path, m.Version)
+        recordDependencyKind(coord, !m.Indirect)
+        if _, hasLoc := lookupLocation(coord); !hasLoc {
+            if line, ok := sumLines[m.Path+"@"+m.Version]; ok {
+                recordLocation(coord, goSumPath, line)
+            }
+        }
+        pkgs = append(pkgs, coord)
+    }
+    return pkgs, nil
+}
+
+// goSumLinePattern matches a go.sum line: "module version hash=" or
+// "module version/go.mod hash=". Only the former pins a real module
+// version; the latter just hashes the go.mod file for that version, which
+// go.sum always lists alongside it.
+var goSumLinePattern = regexp.MustCompile(`^(\S+)\s+(\S+)\s+\S+$`)
+
+// parseGoSumLine parses a single go.sum line into its module and version,
+// reporting ok=false for "/go.mod" hash lines and anything malformed.
+func parseGoSumLine(line string) (mod, version string, ok bool) {
+    matches := goSumLinePattern.FindStringSubmatch(line)
+    if len(matches) != 3 {
+        return "", "", false
+    }
+    mod, version = matches[1], matches[2]
+    if strings.HasSuffix(version, "/go.mod") {
+        return "", "", false
+    }
+    return mod, version, true
+}
+
+// parseGoModViaGoSum falls back to go.sum for the full (transitive)
+// version set when `go list` can't resolve the module graph itself (e.g.
+// no network or empty module cache), and cross-references go.mod's direct
+// `require` lines to preserve the direct/transitive distinction.
+func parseGoModViaGoSum(goModPath, goSumPath string) ([]string, error) {
+    directs, err := parseGoModRequireSet(goModPath)
+    if err != nil {
+        return nil, err
+    }
+
+    f, err := os.Open(goSumPath)
+    if err != nil {
+        return nil, err
+    }
+    defer f.Close()
+
+    seen := make(map[string]bool)
+    var pkgs []string
+    scanner := bufio.NewScanner(f)
+    lineNum := 0
+    for scanner.Scan() {
+        lineNum++
+        mod, version, ok := parseGoSumLine(scanner.Text())
+        if !ok {
+            continue
+        }
+        key := mod + "@" + version
+        if seen[key] {
+            continue
+        }
+        seen[key] = true
+
+        coord := fmt.Sprintf("pkg:golang/%s@%s", mod, version)
+        _, isDirect := directs[mod]
+        recordDependencyKind(coord, isDirect)
+        recordLocation(coord, goSumPath, lineNum)
+        pkgs = append(pkgs, coord)
+    }
+    return pkgs, scanner.Err()
+}
+
+// parseGoSumLineNumbers maps each "module@version" pin in go.sum to the
+// line it appears on, for recordLocation to point SARIF results at when
+// `go list` resolved a module go.sum also pins.
+func parseGoSumLineNumbers(goSumPath string) (map[string]int, error) {
+    f, err := os.Open(goSumPath)
+    if err != nil {
+        return nil, err
+    }
+    defer f.Close()
+
+    lines := make(map[string]int)
+    scanner := bufio.NewScanner(f)
+    lineNum := 0
+    for scanner.Scan() {
+        lineNum++
+        mod, version, ok := parseGoSumLine(scanner.Text())
+        if !ok {
+            continue
+        }
+        key := mod + "@" + version
+        if _, exists := lines[key]; !exists {
+            lines[key] = lineNum
+        }
+    }
+    return lines, scanner.Err()
+}
+
+// goModRequirePattern matches one require-block entry: "module v1.2.3".
+var goModRequirePattern = regexp.MustCompile(`^\s*([^\s]+)\s+(v[0-9A-Za-z\.\-\+]+)`)
+
+// parseGoModRequireSet returns the direct requirements declared in go.mod,
+// keyed by module path, recording each one's manifest location.
+func parseGoModRequireSet(goModPath string) (map[string]string, error) {
+    f, err := os.Open(goModPath)
+    if err != nil {
+        return nil, err
+    }
+    defer f.Close()
+
+    directs := make(map[string]string)
+    scanner := bufio.NewScanner(f)
+    inRequireBlock := false
+    lineNum := 0
+    for scanner.Scan() {
+        lineNum++
+        line := scanner.Text()
+        if strings.HasPrefix(line, "require (") {
+            inRequireBlock = true
+            continue
+        }
+        if inRequireBlock && strings.HasPrefix(line, ")") {
+            inRequireBlock = false
+            continue
+        }
+        if !inRequireBlock && !strings.HasPrefix(line, "require") {
+            continue
+        }
+        if strings.Contains(line, "// indirect") {
+            continue // go.mod already tells us this one isn't direct
+        }
+        entry := line
+        if !inRequireBlock {
+            entry = strings.TrimPrefix(line, "require") // single-line "require module v1.2.3"
+        }
+        if matches := goModRequirePattern.FindStringSubmatch(entry); len(matches) == 3 {
+            directs[matches[1]] = matches[2]
+            recordLocation(fmt.Sprintf("pkg:golang/%s@%s", matches[1], matches[2]), goModPath, lineNum)
+        }
+    }
+    return directs, scanner.Err()
+}
+
+// parseGoModRequireLines is the last-resort fallback, used when neither
+// `go list` nor go.sum is available: it reads only go.mod's direct
+// `require` lines.
+func parseGoModRequireLines(goModPath string) ([]string, error) {
+    directs, err := parseGoModRequireSet(goModPath)
+    if err != nil {
+        return nil, err
+    }
+    pkgs := make([]string, 0, len(directs))
+    for mod, version := range directs {
+        coord := fmt.Sprintf("pkg:golang/%s@%s", mod, version)
+        recordDependencyKind(coord, true)
+        pkgs = append(pkgs, coord)
+    }
+    return pkgs, nil
+}
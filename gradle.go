@@ -0,0 +1,82 @@
+package main
+
+import (
+    "bufio"
+    "fmt"
+    "os"
+    "path/filepath"
+    "regexp"
+    "strings"
+)
+
+type gradleParser struct{}
+
+func (gradleParser) Detect(path string) bool {
+    return fileExists(filepath.Join(path, "build.gradle")) || fileExists(filepath.Join(path, "gradle.lockfile"))
+}
+
+func (gradleParser) Parse(path string) ([]string, error) {
+    var pkgs []string
+    if lockPath := filepath.Join(path, "gradle.lockfile"); fileExists(lockPath) {
+        found, err := parseGradleLockfile(lockPath)
+        if err != nil {
+            return nil, err
+        }
+        pkgs = append(pkgs, found...)
+        return pkgs, nil // the lockfile is already a resolved, de-duped graph
+    }
+    return parseBuildGradle(filepath.Join(path, "build.gradle"))
+}
+
+// gradleCoordPattern matches a "group:artifact:version" dependency
+// coordinate inside a configuration declaration, e.g.
+// implementation 'com.google.guava:guava:31.1-jre'.
+var gradleCoordPattern = regexp.MustCompile(`(?:implementation|api|compile|testImplementation|runtimeOnly)\s*[\(]?['"]([^:'"]+):([^:'"]+):([^'"]+)['"]`)
+
+func parseBuildGradle(path string) ([]string, error) {
+    f, err := os.Open(path)
+    if err != nil {
+        return nil, err
+    }
+    defer f.Close()
+
+    var pkgs []string
+    scanner := bufio.NewScanner(f)
+    lineNum := 0
+    for scanner.Scan() {
+        lineNum++
+        line := scanner.Text()
+        if m := gradleCoordPattern.FindStringSubmatch(line); len(m) == 4 {
+            coord := fmt.Sprintf("pkg:maven/%s/%s@%s", m[1], m[2], m[3])
+            recordLocation(coord, path, lineNum)
+            pkgs = append(pkgs, coord)
+        }
+    }
+    return pkgs, scanner.Err()
+}
+
+// gradleLockLinePattern matches a resolved "group:artifact:version=..."
+// entry in gradle.lockfile.
+var gradleLockLinePattern = regexp.MustCompile(`^([^:=#]+):([^:=#]+):([^:=#]+)=`)
+
+func parseGradleLockfile(path string) ([]string, error) {
+    f, err := os.Open(path)
+    if err != nil {
+        return nil, err
+    }
+    defer f.Close()
+
+    var pkgs []string
+    scanner := bufio.NewScanner(f)
+    lineNum := 0
+    for scanner.Scan() {
+        lineNum++
+        line := strings.TrimSpace(scanner.Text())
+        if m := gradleLockLinePattern.FindStringSubmatch(line); len(m) == 4 {
+            coord := fmt.Sprintf("pkg:maven/%s/%s@%s", m[1], m[2], m[3])
+            recordLocation(coord, path, lineNum)
+            pkgs = append(pkgs, coord)
+        }
+    }
+    return pkgs, scanner.Err()
+}
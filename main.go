@@ -1,19 +1,12 @@
 package main
 
 import (
-    "bufio"
-    "bytes"
-    "encoding/json"
+    "flag"
     "fmt"
-    "io/ioutil"
-    "net/http"
     "os"
-    "path/filepath"
-    "regexp"
     "strings"
+    "time"
     "unicode/utf8"
-
-    "github.com/beevik/etree"
 )
 
 const ossIndexURL = "https://ossindex.sonatype.org/api/v3/component-report"
@@ -208,86 +201,6 @@ func printVulnBox(dep string, vulns []Vulnerability) {
     printBLine(width)
 }
 
-// Parse go.mod for dependencies
-func parseGoMod(goModPath string) ([]string, error) {
-    f, err := os.Open(goModPath)
-    if err != nil {
-        return nil, err
-    }
-    defer f.Close()
-
-    var pkgs []string
-    scanner := bufio.NewScanner(f)
-    depPattern := regexp.MustCompile(`^\s*([^\s]+)\s+v([0-9A-Za-z\.\-\+]+)`)
-    inRequireBlock := false
-
-    for scanner.Scan() {
-        line := scanner.Text()
-        if strings.HasPrefix(line, "require (") {
-            inRequireBlock = true
-            continue
-        }
-        if inRequireBlock && strings.HasPrefix(line, ")") {
-            inRequireBlock = false
-            continue
-        }
-
-        if inRequireBlock || strings.HasPrefix(line, "require") {
-            matches := depPattern.FindStringSubmatch(line)
-            if len(matches) == 3 {
-                mod := matches[1]
-                version := matches[2]
-                pkgs = append(pkgs, fmt.Sprintf("pkg:golang/%s@v%s", mod, version))
-            }
-        }
-    }
-    return pkgs, nil
-}
-
-// Parse pom.xml for Maven dependencies
-func parsePomXML(pomPath string) ([]string, error) {
-    doc := etree.NewDocument()
-    if err := doc.ReadFromFile(pomPath); err != nil {
-        return nil, err
-    }
-
-    var pkgs []string
-    dependencies := doc.FindElements("//project/dependencies/dependency")
-    for _, dep := range dependencies {
-        group := dep.SelectElement("groupId")
-        artifact := dep.SelectElement("artifactId")
-        version := dep.SelectElement("version")
-        if group != nil && artifact != nil && version != nil {
-            pkgs = append(pkgs, fmt.Sprintf("pkg:maven/%s/%s@%s", group.Text(), artifact.Text(), version.Text()))
-        }
-    }
-    return pkgs, nil
-}
-
-// Query OSS Index for vulnerabilities
-func checkVulnerabilities(coords []string) ([]OSSIndexResponse, error) {
-    reqBody, err := json.Marshal(OSSIndexRequest{Coordinates: coords})
-    if err != nil {
-        return nil, err
-    }
-    resp, err := http.Post(ossIndexURL, "application/json", bytes.NewBuffer(reqBody))
-    if err != nil {
-        return nil, err
-    }
-    defer resp.Body.Close()
-
-    body, err := ioutil.ReadAll(resp.Body)
-    if err != nil {
-        return nil, err
-    }
-    var result []OSSIndexResponse
-    err = json.Unmarshal(body, &result)
-    if err != nil {
-        return nil, err
-    }
-    return result, nil
-}
-
 // Print overall report
 func printReport(results []OSSIndexResponse) {
     vulnCount := 0
@@ -296,7 +209,11 @@ func printReport(results []OSSIndexResponse) {
     for _, r := range results {
         if len(r.Vulnerabilities) > 0 {
             depCount++
-            printVulnBox(r.Coordinates, r.Vulnerabilities)
+            label := r.Coordinates
+            if kind := dependencyKindLabel(r.Coordinates); kind != "" {
+                label = fmt.Sprintf("%s  [%s]", label, kind)
+            }
+            printVulnBox(label, r.Vulnerabilities)
             vulnCount += len(r.Vulnerabilities)
         }
     }
@@ -310,37 +227,66 @@ func printReport(results []OSSIndexResponse) {
 
 // Entry point
 func main() {
-    if len(os.Args) < 3 {
-        fmt.Println("Usage: vulnscanner <language> <path_to_project>")
+    reachability := flag.Bool("reachability", false, "for go projects, classify each finding as Reachable, Imported-but-unreachable, or Required-but-unimported")
+    source := flag.String("source", "ossindex", "vulnerability source to query: ossindex, osv, or both")
+    output := flag.String("output", "text", "report format for the sbom subcommand: cyclonedx, text, or json (spdx is rejected with an error)")
+    concurrency := flag.Int("concurrency", 4, "number of OSS Index batches to query concurrently")
+    cacheDir := flag.String("cache-dir", "", "directory to cache OSS Index responses in, keyed by PURL (disabled if empty)")
+    cacheTTL := flag.Duration("cache-ttl", 24*time.Hour, "how long a cached OSS Index response stays valid")
+    noCache := flag.Bool("no-cache", false, "bypass the OSS Index response cache")
+    format := flag.String("format", "text", "report format: text, json, or sarif (the -reachability report is always text)")
+    failOn := flag.String("fail-on", "", "exit non-zero if any finding is at or above this severity: low, medium, high, or critical (not applied by the sbom subcommand)")
+    flag.Parse()
+    args := flag.Args()
+
+    ossCfg := OSSIndexConfig{
+        Concurrency: *concurrency,
+        CacheDir:    *cacheDir,
+        CacheTTL:    *cacheTTL,
+        NoCache:     *noCache,
+    }
+
+    if len(args) < 1 {
+        fmt.Println("Usage: vulnscanner [flags] [language] <path_to_project>")
+        fmt.Println("       vulnscanner [flags] sbom <path_to_sbom_file>")
         fmt.Println("Example for Go: vulnscanner go /path/to/project")
         fmt.Println("Example for Java: vulnscanner java /path/to/project")
+        fmt.Println("Example for an SBOM: vulnscanner sbom /path/to/bom.json")
+        fmt.Println("Example auto-detecting ecosystems: vulnscanner /path/to/project")
+        flag.PrintDefaults()
         os.Exit(1)
     }
 
-    lang := strings.ToLower(os.Args[1])
-    path := os.Args[2]
+    var lang, path string
+    if len(args) >= 2 {
+        lang = strings.ToLower(args[0])
+        path = args[1]
+    } else {
+        path = args[0]
+    }
+
+    if lang == "sbom" {
+        runSBOMCommand(path, *source, *output, ossCfg)
+        return
+    }
+
     var pkgs []string
     var err error
 
-    switch lang {
-    case "go":
-        goModPath := filepath.Join(path, "go.mod")
-        if _, err = os.Stat(goModPath); os.IsNotExist(err) {
-            fmt.Println("go.mod not found in the specified path.")
-            os.Exit(1)
-        }
-        fmt.Println("Parsing go.mod...")
-        pkgs, err = parseGoMod(goModPath)
-    case "java":
-        pomPath := filepath.Join(path, "pom.xml")
-        if _, err = os.Stat(pomPath); os.IsNotExist(err) {
-            fmt.Println("pom.xml not found in the specified path.")
+    if lang == "" {
+        parsers := detectParsers(path)
+        if len(parsers) == 0 {
+            fmt.Println("Could not auto-detect a supported ecosystem in the specified path.")
             os.Exit(1)
         }
-        fmt.Println("Parsing pom.xml...")
-        pkgs, err = parsePomXML(pomPath)
-    default:
-        fmt.Println("Supported languages: go, java")
+        fmt.Println("Parsing dependencies...")
+        pkgs, err = parseAll(parsers, path)
+    } else if parser, ok := parsersByName[lang]; ok {
+        fmt.Println("Parsing dependencies...")
+        pkgs, err = parser.Parse(path)
+        pkgs = dedupePURLs(pkgs)
+    } else {
+        fmt.Println("Supported languages: go, java, npm, pypi, gradle, cargo")
         os.Exit(1)
     }
 
@@ -354,10 +300,68 @@ func main() {
     }
 
     fmt.Printf("Found %d dependencies. Checking vulnerabilities...\n", len(pkgs))
-    results, err := checkVulnerabilities(pkgs)
+    sources, err := sourcesForFlag(*source, ossCfg)
     if err != nil {
-        fmt.Printf("Error querying vulnerabilities: %v\n", err)
+        fmt.Printf("Error: %v\n", err)
+        os.Exit(1)
+    }
+    resultSets := make([][]OSSIndexResponse, 0, len(sources))
+    for _, src := range sources {
+        r, err := src.Query(pkgs)
+        if err != nil {
+            fmt.Printf("Error querying vulnerabilities: %v\n", err)
+            os.Exit(1)
+        }
+        resultSets = append(resultSets, r)
+    }
+    results := mergeResults(resultSets...)
+
+    if lang == "go" && *reachability {
+        findings, err := AnalyzeReachability(path, results)
+        if err != nil {
+            fmt.Printf("Error analyzing reachability: %v\n", err)
+            os.Exit(1)
+        }
+        printReachabilityReport(findings)
+        if *failOn != "" {
+            gate, err := meetsFailOnThreshold(results, *failOn)
+            if err != nil {
+                fmt.Printf("Error: %v\n", err)
+                os.Exit(1)
+            }
+            if gate {
+                os.Exit(1)
+            }
+        }
+        return
+    }
+
+    switch strings.ToLower(*format) {
+    case "", "text":
+        printReport(results)
+    case "json":
+        if err := renderJSONReport(os.Stdout, results); err != nil {
+            fmt.Printf("Error rendering JSON report: %v\n", err)
+            os.Exit(1)
+        }
+    case "sarif":
+        if err := renderSARIFReport(os.Stdout, results); err != nil {
+            fmt.Printf("Error rendering SARIF report: %v\n", err)
+            os.Exit(1)
+        }
+    default:
+        fmt.Printf("Unknown --format value %q (want text, json, or sarif)\n", *format)
         os.Exit(1)
     }
-    printReport(results)
+
+    if *failOn != "" {
+        gate, err := meetsFailOnThreshold(results, *failOn)
+        if err != nil {
+            fmt.Printf("Error: %v\n", err)
+            os.Exit(1)
+        }
+        if gate {
+            os.Exit(1)
+        }
+    }
 }
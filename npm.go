@@ -0,0 +1,140 @@
+package main
+
+import (
+    "bufio"
+    "encoding/json"
+    "fmt"
+    "io/ioutil"
+    "os"
+    "path/filepath"
+    "regexp"
+    "strings"
+)
+
+// npmLockfile covers the parts of package-lock.json the scanner needs,
+// across both the legacy (lockfileVersion 1-2, nested "dependencies") and
+// current (lockfileVersion 2-3, flat "packages") shapes.
+type npmLockfile struct {
+    Dependencies map[string]npmLockDependency `json:"dependencies"`
+    Packages     map[string]npmLockPackage    `json:"packages"`
+}
+
+type npmLockDependency struct {
+    Version      string                       `json:"version"`
+    Dependencies map[string]npmLockDependency `json:"dependencies"`
+}
+
+type npmLockPackage struct {
+    Version string `json:"version"`
+}
+
+type npmParser struct{}
+
+func (npmParser) Detect(path string) bool {
+    return fileExists(filepath.Join(path, "package-lock.json"))
+}
+
+func (npmParser) Parse(path string) ([]string, error) {
+    return parseNpmLock(filepath.Join(path, "package-lock.json"))
+}
+
+// parseNpmLock resolves a package-lock.json's full dependency tree
+// (including transitive deps nested under each package) into PURLs.
+func parseNpmLock(lockPath string) ([]string, error) {
+    data, err := ioutil.ReadFile(lockPath)
+    if err != nil {
+        return nil, err
+    }
+
+    var lock npmLockfile
+    if err := json.Unmarshal(data, &lock); err != nil {
+        return nil, err
+    }
+
+    // Best effort: the flat "packages" map (lockfileVersion 2/3) lists each
+    // dependency's own JSON key line-by-line, so it can be recovered with a
+    // raw-text scan alongside the structured json.Unmarshal above. The
+    // legacy nested "dependencies" tree repeats names at multiple depths,
+    // so its entries are left unlocated.
+    nodeLines, err := npmPackageLines(lockPath)
+    if err != nil {
+        return nil, err
+    }
+
+    seen := make(map[string]bool)
+    var pkgs []string
+    add := func(name, version string, line int) {
+        if name == "" || version == "" {
+            return
+        }
+        coord := npmPURL(name, version)
+        if !seen[coord] {
+            seen[coord] = true
+            if line > 0 {
+                recordLocation(coord, lockPath, line)
+            }
+            pkgs = append(pkgs, coord)
+        }
+    }
+
+    // lockfileVersion 2/3: a flat map keyed by node_modules path, e.g.
+    // "node_modules/lodash" or "node_modules/foo/node_modules/lodash".
+    for nodePath, pkg := range lock.Packages {
+        if nodePath == "" {
+            continue // the root project entry, not a dependency
+        }
+        idx := strings.LastIndex(nodePath, "node_modules/")
+        if idx == -1 {
+            continue // a workspace member, not an installed dependency
+        }
+        name := nodePath[idx+len("node_modules/"):]
+        add(name, pkg.Version, nodeLines[nodePath])
+    }
+
+    // lockfileVersion 1: a nested "dependencies" tree.
+    var walk func(deps map[string]npmLockDependency)
+    walk = func(deps map[string]npmLockDependency) {
+        for name, dep := range deps {
+            add(name, dep.Version, 0)
+            if dep.Dependencies != nil {
+                walk(dep.Dependencies)
+            }
+        }
+    }
+    walk(lock.Dependencies)
+
+    return pkgs, nil
+}
+
+// npmPackagePathPattern matches a "packages" map key line in
+// package-lock.json, e.g. `"node_modules/lodash": {`.
+var npmPackagePathPattern = regexp.MustCompile(`^"(node_modules/[^"]+)":\s*\{`)
+
+// npmPackageLines maps each package-lock.json "packages" entry to the line
+// its key appears on, for recordLocation.
+func npmPackageLines(lockPath string) (map[string]int, error) {
+    f, err := os.Open(lockPath)
+    if err != nil {
+        return nil, err
+    }
+    defer f.Close()
+
+    lines := make(map[string]int)
+    scanner := bufio.NewScanner(f)
+    lineNum := 0
+    for scanner.Scan() {
+        lineNum++
+        line := strings.TrimSpace(scanner.Text())
+        if m := npmPackagePathPattern.FindStringSubmatch(line); m != nil {
+            lines[m[1]] = lineNum
+        }
+    }
+    return lines, scanner.Err()
+}
+
+func npmPURL(name, version string) string {
+    if strings.HasPrefix(name, "@") {
+        return fmt.Sprintf("pkg:npm/%s@%s", strings.Replace(name, "@", "%40", 1), version)
+    }
+    return fmt.Sprintf("pkg:npm/%s@%s", name, version)
+}
@@ -0,0 +1,232 @@
+package main
+
+import (
+    "bytes"
+    "crypto/sha256"
+    "encoding/hex"
+    "encoding/json"
+    "fmt"
+    "io/ioutil"
+    "math"
+    "net/http"
+    "os"
+    "path/filepath"
+    "strconv"
+    "sync"
+    "time"
+)
+
+// ossIndexBatchLimit is the maximum number of coordinates OSS Index accepts
+// in a single component-report request.
+const ossIndexBatchLimit = 128
+
+// OSSIndexConfig controls how OSSIndexSource queries OSS Index: how many
+// batches run concurrently, and how (and whether) responses are cached on
+// disk between runs.
+type OSSIndexConfig struct {
+    Concurrency int
+    CacheDir    string
+    CacheTTL    time.Duration
+    NoCache     bool
+}
+
+func cacheKey(coord string) string {
+    sum := sha256.Sum256([]byte(coord))
+    return hex.EncodeToString(sum[:])
+}
+
+// readCache returns a cached OSSIndexResponse for coord if one exists
+// under cfg.CacheDir and is younger than cfg.CacheTTL.
+func readCache(cfg OSSIndexConfig, coord string) (OSSIndexResponse, bool) {
+    if cfg.NoCache || cfg.CacheDir == "" {
+        return OSSIndexResponse{}, false
+    }
+    path := filepath.Join(cfg.CacheDir, cacheKey(coord)+".json")
+    info, err := os.Stat(path)
+    if err != nil {
+        return OSSIndexResponse{}, false
+    }
+    if cfg.CacheTTL > 0 && time.Since(info.ModTime()) > cfg.CacheTTL {
+        return OSSIndexResponse{}, false
+    }
+    data, err := ioutil.ReadFile(path)
+    if err != nil {
+        return OSSIndexResponse{}, false
+    }
+    var r OSSIndexResponse
+    if err := json.Unmarshal(data, &r); err != nil {
+        return OSSIndexResponse{}, false
+    }
+    return r, true
+}
+
+// writeCache persists a single coordinate's response so the next run of
+// the same project can skip querying for it.
+func writeCache(cfg OSSIndexConfig, r OSSIndexResponse) {
+    if cfg.NoCache || cfg.CacheDir == "" {
+        return
+    }
+    if err := os.MkdirAll(cfg.CacheDir, 0o755); err != nil {
+        return
+    }
+    data, err := json.Marshal(r)
+    if err != nil {
+        return
+    }
+    path := filepath.Join(cfg.CacheDir, cacheKey(r.Coordinates)+".json")
+    _ = ioutil.WriteFile(path, data, 0o644)
+}
+
+func chunkCoords(coords []string, size int) [][]string {
+    if len(coords) == 0 {
+        return nil
+    }
+    var batches [][]string
+    for size < len(coords) {
+        coords, batches = coords[size:], append(batches, coords[:size:size])
+    }
+    return append(batches, coords)
+}
+
+// postComponentReport sends one batch to OSS Index, retrying on 429 with
+// exponential backoff honoring the Retry-After header when present.
+func postComponentReport(batch []string) ([]OSSIndexResponse, error) {
+    reqBody, err := json.Marshal(OSSIndexRequest{Coordinates: batch})
+    if err != nil {
+        return nil, err
+    }
+
+    const maxAttempts = 5
+    backoff := time.Second
+    for attempt := 1; attempt <= maxAttempts; attempt++ {
+        req, err := http.NewRequest(http.MethodPost, ossIndexURL, bytes.NewReader(reqBody))
+        if err != nil {
+            return nil, err
+        }
+        req.Header.Set("Content-Type", "application/json")
+        if user, token := os.Getenv("OSSINDEX_USER"), os.Getenv("OSSINDEX_TOKEN"); user != "" && token != "" {
+            req.SetBasicAuth(user, token)
+        }
+
+        resp, err := http.DefaultClient.Do(req)
+        if err != nil {
+            return nil, err
+        }
+
+        if resp.StatusCode == http.StatusTooManyRequests {
+            wait := retryAfter(resp.Header.Get("Retry-After"), backoff)
+            resp.Body.Close()
+            if attempt == maxAttempts {
+                return nil, fmt.Errorf("OSS Index rate-limited the request after %d attempts", attempt)
+            }
+            time.Sleep(wait)
+            backoff *= 2
+            continue
+        }
+
+        body, err := ioutil.ReadAll(resp.Body)
+        resp.Body.Close()
+        if err != nil {
+            return nil, err
+        }
+        var result []OSSIndexResponse
+        if err := json.Unmarshal(body, &result); err != nil {
+            return nil, err
+        }
+        return result, nil
+    }
+    return nil, fmt.Errorf("unreachable")
+}
+
+// retryAfter parses a Retry-After header (seconds) if present, otherwise
+// falls back to the current exponential backoff duration.
+func retryAfter(header string, fallback time.Duration) time.Duration {
+    if header == "" {
+        return fallback
+    }
+    if secs, err := strconv.Atoi(header); err == nil {
+        return time.Duration(secs) * time.Second
+    }
+    return fallback
+}
+
+// checkVulnerabilitiesWithConfig queries OSS Index for coords using cfg's
+// concurrency, auth, caching, and rate-limit settings: it chunks coords
+// into OSS Index's 128-per-request batches and fans them out across a
+// bounded worker pool, serving cached entries without a network call.
+func checkVulnerabilitiesWithConfig(coords []string, cfg OSSIndexConfig) ([]OSSIndexResponse, error) {
+    var uncached []string
+    var results []OSSIndexResponse
+    for _, c := range coords {
+        if cached, ok := readCache(cfg, c); ok {
+            results = append(results, cached)
+        } else {
+            uncached = append(uncached, c)
+        }
+    }
+
+    concurrency := cfg.Concurrency
+    if concurrency <= 0 {
+        concurrency = 1
+    }
+    batches := chunkCoords(uncached, ossIndexBatchLimit)
+
+    type batchResult struct {
+        responses []OSSIndexResponse
+        err       error
+    }
+    jobs := make(chan []string)
+    out := make(chan batchResult)
+    var wg sync.WaitGroup
+
+    workers := int(math.Min(float64(concurrency), float64(maxInt(len(batches), 1))))
+    for i := 0; i < workers; i++ {
+        wg.Add(1)
+        go func() {
+            defer wg.Done()
+            for batch := range jobs {
+                responses, err := postComponentReport(batch)
+                out <- batchResult{responses: responses, err: err}
+            }
+        }()
+    }
+    go func() {
+        for _, b := range batches {
+            jobs <- b
+        }
+        close(jobs)
+    }()
+    go func() {
+        wg.Wait()
+        close(out)
+    }()
+
+    // Keep draining out until every worker has finished its batch (and the
+    // closer goroutine closes the channel) even after the first error, so
+    // an early return can't leave workers blocked forever on out<- for a
+    // result nobody's left to receive.
+    var firstErr error
+    for br := range out {
+        if br.err != nil {
+            if firstErr == nil {
+                firstErr = br.err
+            }
+            continue
+        }
+        for _, r := range br.responses {
+            writeCache(cfg, r)
+            results = append(results, r)
+        }
+    }
+    if firstErr != nil {
+        return nil, firstErr
+    }
+    return results, nil
+}
+
+func maxInt(a, b int) int {
+    if a > b {
+        return a
+    }
+    return b
+}
@@ -0,0 +1,256 @@
+package main
+
+import (
+    "encoding/json"
+    "fmt"
+    "io/ioutil"
+    "net/http"
+    "strconv"
+    "strings"
+)
+
+// The Go vulnerability database (vuln.go.dev) is an OSV-formatted index
+// maintained by the Go team. It publishes a compact module index so
+// clients don't have to download every entry, and one JSON document per
+// module containing that module's entries.
+const (
+    osvModuleIndexURL = "https://vuln.go.dev/index/modules.json"
+    osvModuleEntryURL = "https://vuln.go.dev/%s.json"
+)
+
+// osvModuleIndexEntry is one row of the modules index: a module path and
+// the time its entries were last updated. Only Path is needed here.
+type osvModuleIndexEntry struct {
+    Path string `json:"path"`
+}
+
+// OSVEvent is a single point in an affected range: either the version a
+// vulnerability was introduced in, or the version it was fixed in.
+type OSVEvent struct {
+    Introduced string `json:"introduced,omitempty"`
+    Fixed      string `json:"fixed,omitempty"`
+}
+
+// OSVRange is one SEMVER range within an affected package.
+type OSVRange struct {
+    Type   string     `json:"type"`
+    Events []OSVEvent `json:"events"`
+}
+
+// OSVPackage identifies the ecosystem and module affected by an entry.
+type OSVPackage struct {
+    Ecosystem string `json:"ecosystem"`
+    Name      string `json:"name"`
+}
+
+// OSVAffected is one package (and its affected version ranges) within an
+// OSV entry.
+type OSVAffected struct {
+    Package OSVPackage `json:"package"`
+    Ranges  []OSVRange `json:"ranges"`
+}
+
+// OSVSeverity carries a scoring system name and its score, e.g. CVSS_V3.
+type OSVSeverity struct {
+    Type  string `json:"type"`
+    Score string `json:"score"`
+}
+
+// OSVReference is a related URL, e.g. an advisory or fix commit.
+type OSVReference struct {
+    Type string `json:"type"`
+    URL  string `json:"url"`
+}
+
+// OSVEntry is a single vulnerability record in OSV's schema
+// (https://ossf.github.io/osv-schema/).
+type OSVEntry struct {
+    ID         string         `json:"id"`
+    Summary    string         `json:"summary"`
+    Details    string         `json:"details"`
+    Aliases    []string       `json:"aliases"`
+    Affected   []OSVAffected  `json:"affected"`
+    Severity   []OSVSeverity  `json:"severity"`
+    References []OSVReference `json:"references"`
+}
+
+// OSVSource queries the Go vulnerability database for OSV entries.
+type OSVSource struct{}
+
+// Query implements VulnSource. It downloads the module index once, then
+// fetches and filters entries only for modules present in coords.
+func (s OSVSource) Query(coords []string) ([]OSSIndexResponse, error) {
+    indexed, err := fetchOSVModuleIndex()
+    if err != nil {
+        return nil, fmt.Errorf("fetching OSV module index: %w", err)
+    }
+
+    results := make([]OSSIndexResponse, 0, len(coords))
+    for _, coord := range coords {
+        p, err := parsePURL(coord)
+        if err != nil || p.Type != "golang" {
+            continue
+        }
+        modulePath := p.Name
+        if p.Namespace != "" {
+            modulePath = p.Namespace + "/" + p.Name
+        }
+        if !indexed[modulePath] {
+            continue
+        }
+
+        entries, err := fetchOSVEntriesForModule(modulePath)
+        if err != nil {
+            return nil, fmt.Errorf("fetching OSV entries for %s: %w", modulePath, err)
+        }
+
+        var vulns []Vulnerability
+        for _, e := range entries {
+            if !osvEntryAffectsVersion(e, modulePath, p.Version) {
+                continue
+            }
+            vulns = append(vulns, osvEntryToVulnerability(e))
+        }
+        if len(vulns) > 0 {
+            results = append(results, OSSIndexResponse{Coordinates: coord, Vulnerabilities: vulns})
+        }
+    }
+    return results, nil
+}
+
+func fetchOSVModuleIndex() (map[string]bool, error) {
+    resp, err := http.Get(osvModuleIndexURL)
+    if err != nil {
+        return nil, err
+    }
+    defer resp.Body.Close()
+
+    body, err := ioutil.ReadAll(resp.Body)
+    if err != nil {
+        return nil, err
+    }
+    var entries []osvModuleIndexEntry
+    if err := json.Unmarshal(body, &entries); err != nil {
+        return nil, err
+    }
+
+    indexed := make(map[string]bool, len(entries))
+    for _, e := range entries {
+        indexed[e.Path] = true
+    }
+    return indexed, nil
+}
+
+func fetchOSVEntriesForModule(modulePath string) ([]OSVEntry, error) {
+    url := fmt.Sprintf(osvModuleEntryURL, modulePath)
+    resp, err := http.Get(url)
+    if err != nil {
+        return nil, err
+    }
+    defer resp.Body.Close()
+
+    body, err := ioutil.ReadAll(resp.Body)
+    if err != nil {
+        return nil, err
+    }
+    var entries []OSVEntry
+    if err := json.Unmarshal(body, &entries); err != nil {
+        return nil, err
+    }
+    return entries, nil
+}
+
+// osvEntryAffectsVersion reports whether the entry lists modulePath as
+// affected at the resolved version, per its semver ranges.
+func osvEntryAffectsVersion(e OSVEntry, modulePath, version string) bool {
+    for _, a := range e.Affected {
+        if a.Package.Ecosystem != "Go" || a.Package.Name != modulePath {
+            continue
+        }
+        for _, r := range a.Ranges {
+            if r.Type != "SEMVER" {
+                continue
+            }
+            if versionInRange(version, r) {
+                return true
+            }
+        }
+    }
+    return false
+}
+
+// versionInRange walks a range's introduced/fixed events in order and
+// reports whether version falls within an [introduced, fixed) window.
+func versionInRange(version string, r OSVRange) bool {
+    introduced := ""
+    for _, ev := range r.Events {
+        switch {
+        case ev.Introduced != "":
+            introduced = ev.Introduced
+        case ev.Fixed != "":
+            if introduced == "" {
+                continue
+            }
+            if semverCompare(version, introduced) >= 0 && semverCompare(version, ev.Fixed) < 0 {
+                return true
+            }
+            introduced = ""
+        }
+    }
+    // An introduced event with no matching fixed event means "still affected".
+    return introduced != "" && semverCompare(version, introduced) >= 0
+}
+
+// semverCompare compares two "vX.Y.Z"-ish version strings numerically,
+// component by component, returning -1, 0, or 1. Non-numeric components
+// compare as equal so pre-release suffixes don't break comparison.
+func semverCompare(a, b string) int {
+    aParts := strings.Split(strings.TrimPrefix(a, "v"), ".")
+    bParts := strings.Split(strings.TrimPrefix(b, "v"), ".")
+    for i := 0; i < len(aParts) || i < len(bParts); i++ {
+        var an, bn int
+        if i < len(aParts) {
+            an, _ = strconv.Atoi(strings.SplitN(aParts[i], "-", 2)[0])
+        }
+        if i < len(bParts) {
+            bn, _ = strconv.Atoi(strings.SplitN(bParts[i], "-", 2)[0])
+        }
+        if an != bn {
+            if an < bn {
+                return -1
+            }
+            return 1
+        }
+    }
+    return 0
+}
+
+// osvEntryToVulnerability normalizes an OSV entry into the scanner's own
+// Vulnerability struct so OSV and OSS Index findings can share a report.
+func osvEntryToVulnerability(e OSVEntry) Vulnerability {
+    v := Vulnerability{
+        ID:          e.ID,
+        Title:       e.Summary,
+        Description: e.Details,
+    }
+    if v.Title == "" {
+        v.Title = e.ID
+    }
+    for _, alias := range e.Aliases {
+        if strings.HasPrefix(alias, "CVE-") {
+            v.CVE = alias
+            break
+        }
+    }
+    for _, sev := range e.Severity {
+        if sev.Type == "CVSS_V3" || sev.Type == "CVSS_V2" {
+            if score, err := parseCVSSVectorScore(sev.Score); err == nil {
+                v.CVSSScore = score
+            }
+        }
+    }
+    if len(e.References) > 0 {
+        v.Reference = e.References[0].URL
+    }
+    return v
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+    "os"
+    "path/filepath"
+)
+
+// Parser knows how to recognize and extract dependency coordinates from one
+// ecosystem's manifest or lockfile.
+type Parser interface {
+    // Detect reports whether this parser's manifest is present in the
+    // project directory at path.
+    Detect(path string) bool
+    // Parse extracts package URLs from the project directory at path.
+    Parse(path string) ([]string, error)
+}
+
+// allParsers is the registry of ecosystems the scanner knows how to read.
+// Order matters only for combined-report output ordering.
+var allParsers = []Parser{
+    goModParser{},
+    pomXMLParser{},
+    npmParser{},
+    pypiParser{},
+    gradleParser{},
+    cargoParser{},
+}
+
+// parsersByName maps the language names accepted on the command line to the
+// parser that handles them, preserving the original "go"/"java" spelling
+// alongside the new ecosystems.
+var parsersByName = map[string]Parser{
+    "go":     goModParser{},
+    "java":   pomXMLParser{},
+    "npm":    npmParser{},
+    "pypi":   pypiParser{},
+    "gradle": gradleParser{},
+    "cargo":  cargoParser{},
+}
+
+type goModParser struct{}
+
+func (goModParser) Detect(path string) bool {
+    return fileExists(filepath.Join(path, "go.mod"))
+}
+
+func (goModParser) Parse(path string) ([]string, error) {
+    return parseGoMod(filepath.Join(path, "go.mod"))
+}
+
+type pomXMLParser struct{}
+
+func (pomXMLParser) Detect(path string) bool {
+    return fileExists(filepath.Join(path, "pom.xml"))
+}
+
+func (pomXMLParser) Parse(path string) ([]string, error) {
+    return parsePomXML(filepath.Join(path, "pom.xml"))
+}
+
+func fileExists(path string) bool {
+    _, err := os.Stat(path)
+    return err == nil
+}
+
+// detectParsers returns every registered parser whose manifest is present
+// in path, for auto-detection when the user supplies only a path.
+func detectParsers(path string) []Parser {
+    var detected []Parser
+    for _, p := range allParsers {
+        if p.Detect(path) {
+            detected = append(detected, p)
+        }
+    }
+    return detected
+}
+
+// parseAll runs every given parser over path and concatenates their
+// package URLs into a single combined dependency set.
+func parseAll(parsers []Parser, path string) ([]string, error) {
+    var pkgs []string
+    for _, p := range parsers {
+        found, err := p.Parse(path)
+        if err != nil {
+            return nil, err
+        }
+        pkgs = append(pkgs, found...)
+    }
+    return dedupePURLs(pkgs), nil
+}
+
+// dedupePURLs drops repeat PURLs, keeping the first occurrence's order.
+// A single manifest (e.g. pypi's requirements.txt + poetry.lock) or two
+// different manifests in the same project can resolve the same
+// package+version, and each should only be queried/reported once.
+func dedupePURLs(pkgs []string) []string {
+    seen := make(map[string]bool, len(pkgs))
+    deduped := make([]string, 0, len(pkgs))
+    for _, coord := range pkgs {
+        if seen[coord] {
+            continue
+        }
+        seen[coord] = true
+        deduped = append(deduped, coord)
+    }
+    return deduped
+}
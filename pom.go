@@ -0,0 +1,340 @@
+package main
+
+import (
+    "bufio"
+    "fmt"
+    "io/ioutil"
+    "net/http"
+    "os"
+    "regexp"
+    "strings"
+
+    "github.com/beevik/etree"
+)
+
+const mavenCentralBaseURL = "https://repo1.maven.org/maven2"
+
+// pomMaxDepth bounds both the <parent> chain walk and the transitive
+// dependency walk, so a cycle or an unexpectedly deep graph can't hang the
+// scan.
+const pomMaxDepth = 12
+
+// gav identifies a Maven artifact by its coordinates.
+type gav struct {
+    GroupID    string
+    ArtifactID string
+    Version    string
+}
+
+func (g gav) key() string { return g.GroupID + ":" + g.ArtifactID }
+
+// parsePomXML computes the effective POM for the project at pomPath:
+// resolving its <parent> chain and <dependencyManagement> (including BOM
+// <scope>import</scope> entries) to fill in versions left unspecified on
+// its direct <dependencies>, then walking each direct dependency's own POM
+// to compute the transitive closure. Direct and transitive dependencies
+// are both returned, with their kind recorded via recordDependencyKind.
+func parsePomXML(pomPath string) ([]string, error) {
+    doc := etree.NewDocument()
+    if err := doc.ReadFromFile(pomPath); err != nil {
+        return nil, err
+    }
+    root := doc.SelectElement("project")
+    if root == nil {
+        return nil, fmt.Errorf("%s: missing <project> root element", pomPath)
+    }
+
+    props := make(map[string]string)
+    mgmt := make(map[string]string) // "groupId:artifactId" -> version
+    collectProperties(root, props)
+    if err := collectDependencyManagement(root, props, mgmt, 0); err != nil {
+        return nil, err
+    }
+    if err := walkParentChain(root, props, mgmt, 0); err != nil {
+        return nil, err
+    }
+
+    var direct []gav
+    for _, dep := range root.FindElements("dependencies/dependency") {
+        g, ok := resolveDependency(dep, props, mgmt)
+        if ok {
+            direct = append(direct, g)
+        }
+    }
+
+    // Only direct dependencies are literally written in pomPath; a
+    // transitive dependency's location is in some other project's POM, not
+    // this one, so it's left unrecorded rather than pointed at a line that
+    // doesn't actually mention it.
+    depLines, err := pomDependencyLocations(pomPath)
+    if err != nil {
+        return nil, err
+    }
+
+    seen := make(map[string]bool)
+    var pkgs []string
+    add := func(g gav, isDirect bool) {
+        if seen[g.key()] {
+            return
+        }
+        seen[g.key()] = true
+        coord := fmt.Sprintf("pkg:maven/%s/%s@%s", g.GroupID, g.ArtifactID, g.Version)
+        recordDependencyKind(coord, isDirect)
+        if line, ok := depLines[g.key()]; ok {
+            recordLocation(coord, pomPath, line)
+        }
+        pkgs = append(pkgs, coord)
+    }
+
+    for _, g := range direct {
+        add(g, true)
+    }
+    for _, g := range direct {
+        collectTransitiveDeps(g, 0, seen, add)
+    }
+
+    return pkgs, nil
+}
+
+// collectProperties merges a POM's <properties> block into props, without
+// overwriting properties already set by a more-derived POM (root wins over
+// parents).
+func collectProperties(project *etree.Element, props map[string]string) {
+    propsEl := project.SelectElement("properties")
+    if propsEl == nil {
+        return
+    }
+    for _, child := range propsEl.ChildElements() {
+        if _, exists := props[child.Tag]; !exists {
+            props[child.Tag] = strings.TrimSpace(child.Text())
+        }
+    }
+}
+
+// collectDependencyManagement merges a POM's <dependencyManagement>
+// entries into mgmt, following BOM <scope>import</scope> entries.
+func collectDependencyManagement(project *etree.Element, props map[string]string, mgmt map[string]string, depth int) error {
+    if depth > pomMaxDepth {
+        return fmt.Errorf("dependencyManagement import chain exceeds depth %d", pomMaxDepth)
+    }
+    dm := project.SelectElement("dependencyManagement")
+    if dm == nil {
+        return nil
+    }
+    for _, dep := range dm.FindElements("dependencies/dependency") {
+        scope := elementText(dep, "scope")
+        typ := elementText(dep, "type")
+        groupID := interpolate(elementText(dep, "groupId"), props)
+        artifactID := elementText(dep, "artifactId")
+        version := interpolate(elementText(dep, "version"), props)
+        if groupID == "" || artifactID == "" {
+            continue
+        }
+        key := groupID + ":" + artifactID
+
+        if scope == "import" && typ == "pom" {
+            bomProject, err := fetchPOM(gav{GroupID: groupID, ArtifactID: artifactID, Version: version})
+            if err != nil {
+                return fmt.Errorf("importing BOM %s: %w", key, err)
+            }
+            if err := collectDependencyManagement(bomProject, props, mgmt, depth+1); err != nil {
+                return err
+            }
+            continue
+        }
+        if version != "" {
+            if _, exists := mgmt[key]; !exists {
+                mgmt[key] = version
+            }
+        }
+    }
+    return nil
+}
+
+// walkParentChain follows <parent> up to the root POM, merging each
+// ancestor's properties and dependencyManagement (nearest-wins, so the
+// child's values already in props/mgmt are never overwritten).
+func walkParentChain(project *etree.Element, props map[string]string, mgmt map[string]string, depth int) error {
+    if depth > pomMaxDepth {
+        return fmt.Errorf("parent chain exceeds depth %d", pomMaxDepth)
+    }
+    parent := project.SelectElement("parent")
+    if parent == nil {
+        return nil
+    }
+    g := gav{
+        GroupID:    elementText(parent, "groupId"),
+        ArtifactID: elementText(parent, "artifactId"),
+        Version:    interpolate(elementText(parent, "version"), props),
+    }
+    parentProject, err := fetchPOM(g)
+    if err != nil {
+        return fmt.Errorf("fetching parent POM %s:%s: %w", g.GroupID, g.ArtifactID, err)
+    }
+
+    collectProperties(parentProject, props)
+    if err := collectDependencyManagement(parentProject, props, mgmt, depth+1); err != nil {
+        return err
+    }
+    return walkParentChain(parentProject, props, mgmt, depth+1)
+}
+
+// resolveDependency turns a <dependency> element into a gav, filling in a
+// missing <version> from dependencyManagement. It skips optional and
+// non-runtime-scoped (test/provided/system) dependencies, matching what a
+// Maven build actually ships.
+func resolveDependency(dep *etree.Element, props map[string]string, mgmt map[string]string) (gav, bool) {
+    if elementText(dep, "optional") == "true" {
+        return gav{}, false
+    }
+    switch elementText(dep, "scope") {
+    case "test", "provided", "system":
+        return gav{}, false
+    }
+
+    groupID := interpolate(elementText(dep, "groupId"), props)
+    artifactID := elementText(dep, "artifactId")
+    version := interpolate(elementText(dep, "version"), props)
+    if groupID == "" || artifactID == "" {
+        return gav{}, false
+    }
+    if version == "" {
+        version = mgmt[groupID+":"+artifactID]
+    }
+    if version == "" {
+        return gav{}, false
+    }
+    return gav{GroupID: groupID, ArtifactID: artifactID, Version: version}, true
+}
+
+// collectTransitiveDeps fetches g's own POM and recurses into its
+// dependencies, calling add for each newly discovered artifact. Maven's
+// real resolution uses nearest-wins version mediation; this mirrors that
+// by skipping any groupId:artifactId the caller has already added (direct
+// dependencies, resolved first, always win).
+func collectTransitiveDeps(g gav, depth int, seen map[string]bool, add func(gav, bool)) {
+    if depth > pomMaxDepth {
+        return
+    }
+    project, err := fetchPOM(g)
+    if err != nil {
+        return // best-effort: an unreachable transitive POM shouldn't fail the whole scan
+    }
+
+    props := make(map[string]string)
+    mgmt := make(map[string]string)
+    collectProperties(project, props)
+    _ = collectDependencyManagement(project, props, mgmt, 0)
+    _ = walkParentChain(project, props, mgmt, 0)
+
+    for _, dep := range project.FindElements("dependencies/dependency") {
+        child, ok := resolveDependency(dep, props, mgmt)
+        if !ok || seen[child.key()] {
+            continue
+        }
+        add(child, false)
+        collectTransitiveDeps(child, depth+1, seen, add)
+    }
+}
+
+// fetchPOM downloads and parses a single POM from Maven Central.
+func fetchPOM(g gav) (*etree.Element, error) {
+    groupPath := strings.ReplaceAll(g.GroupID, ".", "/")
+    url := fmt.Sprintf("%s/%s/%s/%s/%s-%s.pom", mavenCentralBaseURL, groupPath, g.ArtifactID, g.Version, g.ArtifactID, g.Version)
+
+    resp, err := http.Get(url)
+    if err != nil {
+        return nil, err
+    }
+    defer resp.Body.Close()
+    if resp.StatusCode != http.StatusOK {
+        return nil, fmt.Errorf("fetching %s: HTTP %d", url, resp.StatusCode)
+    }
+
+    body, err := ioutil.ReadAll(resp.Body)
+    if err != nil {
+        return nil, err
+    }
+    doc := etree.NewDocument()
+    if err := doc.ReadFromBytes(body); err != nil {
+        return nil, fmt.Errorf("parsing %s: %w", url, err)
+    }
+    project := doc.SelectElement("project")
+    if project == nil {
+        return nil, fmt.Errorf("%s: missing <project> root element", url)
+    }
+    return project, nil
+}
+
+var (
+    pomGroupIDPattern    = regexp.MustCompile(`<groupId>([^<]+)</groupId>`)
+    pomArtifactIDPattern = regexp.MustCompile(`<artifactId>([^<]+)</artifactId>`)
+)
+
+// pomDependencyLocations scans pomPath's raw text for the line each direct
+// <dependencies><dependency> block starts on, keyed by "groupId:artifactId".
+// It skips anything nested inside <dependencyManagement>, since those
+// entries only pin a version and aren't themselves resolved dependencies.
+func pomDependencyLocations(pomPath string) (map[string]int, error) {
+    f, err := os.Open(pomPath)
+    if err != nil {
+        return nil, err
+    }
+    defer f.Close()
+
+    locations := make(map[string]int)
+    inDepMgmt, inExclusions := false, false
+    depLine, groupID := 0, ""
+    scanner := bufio.NewScanner(f)
+    lineNum := 0
+    for scanner.Scan() {
+        lineNum++
+        line := strings.TrimSpace(scanner.Text())
+        switch {
+        case strings.HasPrefix(line, "<dependencyManagement"):
+            inDepMgmt = true
+        case strings.HasPrefix(line, "</dependencyManagement"):
+            inDepMgmt = false
+        case strings.HasPrefix(line, "<exclusions"):
+            inExclusions = true
+        case strings.HasPrefix(line, "</exclusions"):
+            inExclusions = false
+        case strings.HasPrefix(line, "<dependency>") || strings.HasPrefix(line, "<dependency "):
+            depLine, groupID = lineNum, ""
+        case inDepMgmt || inExclusions:
+            // dependencyManagement entries and excluded transitive
+            // artifacts don't locate this resolved dependency itself
+        case pomGroupIDPattern.MatchString(line):
+            groupID = pomGroupIDPattern.FindStringSubmatch(line)[1]
+        case groupID != "":
+            if m := pomArtifactIDPattern.FindStringSubmatch(line); m != nil {
+                if _, exists := locations[groupID+":"+m[1]]; !exists {
+                    locations[groupID+":"+m[1]] = depLine
+                }
+                groupID = ""
+            }
+        }
+    }
+    return locations, scanner.Err()
+}
+
+func elementText(parent *etree.Element, tag string) string {
+    el := parent.SelectElement(tag)
+    if el == nil {
+        return ""
+    }
+    return strings.TrimSpace(el.Text())
+}
+
+// interpolate substitutes "${property}" references using props. Maven's
+// built-in properties (e.g. ${project.version}) are not resolved; a
+// reference with no entry in props is left in the value as written.
+func interpolate(value string, props map[string]string) string {
+    if !strings.Contains(value, "${") {
+        return value
+    }
+    for k, v := range props {
+        value = strings.ReplaceAll(value, "${"+k+"}", v)
+    }
+    return value
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+    "fmt"
+    "strings"
+)
+
+// parsedPURL is the subset of a package URL (https://github.com/package-url/purl-spec)
+// that the scanner's sources and parsers need to resolve and compare.
+type parsedPURL struct {
+    Type      string // e.g. "golang", "maven", "npm"
+    Namespace string // e.g. a Maven groupId or npm scope; empty when not applicable
+    Name      string
+    Version   string
+}
+
+// parsePURL splits a "pkg:<type>/<namespace>/<name>@<version>" coordinate
+// into its components. The namespace segment is optional.
+func parsePURL(coord string) (parsedPURL, error) {
+    if !strings.HasPrefix(coord, "pkg:") {
+        return parsedPURL{}, fmt.Errorf("not a purl: %s", coord)
+    }
+    rest := strings.TrimPrefix(coord, "pkg:")
+
+    typeAndPath := strings.SplitN(rest, "/", 2)
+    if len(typeAndPath) != 2 {
+        return parsedPURL{}, fmt.Errorf("malformed purl, missing type or path: %s", coord)
+    }
+    p := parsedPURL{Type: typeAndPath[0]}
+
+    pathAndVersion := strings.SplitN(typeAndPath[1], "@", 2)
+    if len(pathAndVersion) != 2 || pathAndVersion[1] == "" {
+        return parsedPURL{}, fmt.Errorf("malformed purl, missing version: %s", coord)
+    }
+    p.Version = pathAndVersion[1]
+
+    segments := strings.Split(pathAndVersion[0], "/")
+    p.Name = segments[len(segments)-1]
+    if len(segments) > 1 {
+        p.Namespace = strings.Join(segments[:len(segments)-1], "/")
+    }
+    if p.Name == "" {
+        return parsedPURL{}, fmt.Errorf("malformed purl, empty name: %s", coord)
+    }
+    return p, nil
+}
+
+// formatPURL reassembles a parsedPURL back into its string form.
+func formatPURL(p parsedPURL) string {
+    path := p.Name
+    if p.Namespace != "" {
+        path = p.Namespace + "/" + p.Name
+    }
+    return fmt.Sprintf("pkg:%s/%s@%s", p.Type, path, p.Version)
+}
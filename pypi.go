@@ -0,0 +1,113 @@
+package main
+
+import (
+    "bufio"
+    "fmt"
+    "os"
+    "path/filepath"
+    "regexp"
+    "strings"
+)
+
+type pypiParser struct{}
+
+func (pypiParser) Detect(path string) bool {
+    return fileExists(filepath.Join(path, "requirements.txt")) || fileExists(filepath.Join(path, "poetry.lock"))
+}
+
+func (pypiParser) Parse(path string) ([]string, error) {
+    var pkgs []string
+    if reqPath := filepath.Join(path, "requirements.txt"); fileExists(reqPath) {
+        found, err := parseRequirementsTxt(reqPath)
+        if err != nil {
+            return nil, err
+        }
+        pkgs = append(pkgs, found...)
+    }
+    if lockPath := filepath.Join(path, "poetry.lock"); fileExists(lockPath) {
+        found, err := parsePoetryLock(lockPath)
+        if err != nil {
+            return nil, err
+        }
+        pkgs = append(pkgs, found...)
+    }
+    return pkgs, nil
+}
+
+// pinnedPattern matches a requirements.txt line pinning an exact version,
+// e.g. "requests==2.31.0". Looser constraints (>=, ~=, unpinned) can't be
+// resolved to a single version, so they're skipped like parseGoMod skips
+// malformed require lines.
+var pinnedPattern = regexp.MustCompile(`^([A-Za-z0-9_.\-]+)\s*==\s*([A-Za-z0-9_.\-]+)`)
+
+// parseRequirementsTxt extracts exact-pinned packages from requirements.txt.
+func parseRequirementsTxt(reqPath string) ([]string, error) {
+    f, err := os.Open(reqPath)
+    if err != nil {
+        return nil, err
+    }
+    defer f.Close()
+
+    var pkgs []string
+    scanner := bufio.NewScanner(f)
+    lineNum := 0
+    for scanner.Scan() {
+        lineNum++
+        line := strings.TrimSpace(scanner.Text())
+        if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "-") {
+            continue
+        }
+        if matches := pinnedPattern.FindStringSubmatch(line); len(matches) == 3 {
+            coord := fmt.Sprintf("pkg:pypi/%s@%s", strings.ToLower(matches[1]), matches[2])
+            recordLocation(coord, reqPath, lineNum)
+            pkgs = append(pkgs, coord)
+        }
+    }
+    return pkgs, scanner.Err()
+}
+
+var (
+    poetryNamePattern    = regexp.MustCompile(`^name\s*=\s*"([^"]+)"`)
+    poetryVersionPattern = regexp.MustCompile(`^version\s*=\s*"([^"]+)"`)
+)
+
+// parsePoetryLock reads the [[package]] blocks of a poetry.lock TOML file.
+// It's a line-oriented scan rather than a full TOML parser, matching the
+// same regex-per-line approach parseGoMod uses for go.mod.
+func parsePoetryLock(lockPath string) ([]string, error) {
+    f, err := os.Open(lockPath)
+    if err != nil {
+        return nil, err
+    }
+    defer f.Close()
+
+    var pkgs []string
+    var name, version string
+    blockLine := 0
+    scanner := bufio.NewScanner(f)
+    flush := func() {
+        if name != "" && version != "" {
+            coord := fmt.Sprintf("pkg:pypi/%s@%s", strings.ToLower(name), version)
+            recordLocation(coord, lockPath, blockLine)
+            pkgs = append(pkgs, coord)
+        }
+        name, version = "", ""
+    }
+    lineNum := 0
+    for scanner.Scan() {
+        lineNum++
+        line := strings.TrimSpace(scanner.Text())
+        if line == "[[package]]" {
+            flush()
+            blockLine = lineNum
+            continue
+        }
+        if m := poetryNamePattern.FindStringSubmatch(line); m != nil {
+            name = m[1]
+        } else if m := poetryVersionPattern.FindStringSubmatch(line); m != nil {
+            version = m[1]
+        }
+    }
+    flush()
+    return pkgs, scanner.Err()
+}
@@ -0,0 +1,251 @@
+package main
+
+import (
+    "fmt"
+    "regexp"
+    "strings"
+
+    "golang.org/x/tools/go/callgraph"
+    "golang.org/x/tools/go/callgraph/cha"
+    "golang.org/x/tools/go/callgraph/vta"
+    "golang.org/x/tools/go/packages"
+    "golang.org/x/tools/go/ssa"
+    "golang.org/x/tools/go/ssa/ssautil"
+)
+
+// Reachability classifies how exposed a vulnerable symbol is to the
+// project's own call graph.
+type Reachability int
+
+const (
+    Reachable Reachability = iota
+    ImportedUnreachable
+    RequiredUnimported
+)
+
+func (r Reachability) String() string {
+    switch r {
+    case Reachable:
+        return "Reachable"
+    case ImportedUnreachable:
+        return "Imported but unreachable"
+    default:
+        return "Required but unimported"
+    }
+}
+
+// ReachabilityFinding pairs a vulnerability with its reachability verdict
+// for a single dependency.
+type ReachabilityFinding struct {
+    Dependency    string
+    Vulnerability Vulnerability
+    Status        Reachability
+}
+
+// symbolPattern pulls dotted Go identifiers (e.g. "pkg.Func" or
+// "pkg.Type.Method") out of free-form vulnerability text. It's a heuristic:
+// OSS Index descriptions rarely name symbols in a structured way.
+var symbolPattern = regexp.MustCompile(`\b([a-zA-Z_][a-zA-Z0-9_]*(?:/[a-zA-Z_][a-zA-Z0-9_]*)*\.[A-Z][a-zA-Z0-9_]*(?:\.[A-Z][a-zA-Z0-9_]*)?)\b`)
+
+// extractAffectedSymbols scrapes candidate exported symbol names from a
+// vulnerability's title and description.
+func extractAffectedSymbols(v Vulnerability) []string {
+    var symbols []string
+    seen := make(map[string]bool)
+    for _, text := range []string{v.Title, v.Description} {
+        for _, m := range symbolPattern.FindAllString(text, -1) {
+            if !seen[m] {
+                seen[m] = true
+                symbols = append(symbols, m)
+            }
+        }
+    }
+    return symbols
+}
+
+// buildCallGraph loads the project at path in LoadAllSyntax mode, builds an
+// SSA program for it, and computes a whole-program call graph via VTA
+// (seeded with a CHA call graph, as govulncheck does) rooted at the
+// package's main function. It also returns every package path reachable
+// from the program's import graph, so callers can tell a genuinely
+// imported package from one that's merely required in go.mod/go.sum.
+func buildCallGraph(path string) (*callgraph.Graph, *ssa.Function, map[string]bool, error) {
+    cfg := &packages.Config{
+        Mode: packages.LoadAllSyntax,
+        Dir:  path,
+    }
+    pkgs, err := packages.Load(cfg, "./...")
+    if err != nil {
+        return nil, nil, nil, fmt.Errorf("loading packages: %w", err)
+    }
+    if packages.PrintErrors(pkgs) > 0 {
+        return nil, nil, nil, fmt.Errorf("errors loading packages at %s", path)
+    }
+
+    prog, ssaPkgs := ssautil.AllPackages(pkgs, ssa.SanityCheckFunctions)
+    prog.Build()
+
+    var mainFn *ssa.Function
+    for _, p := range ssaPkgs {
+        if p != nil && p.Pkg.Name() == "main" {
+            if fn := p.Func("main"); fn != nil {
+                mainFn = fn
+                break
+            }
+        }
+    }
+    if mainFn == nil {
+        return nil, nil, nil, fmt.Errorf("no main function found under %s", path)
+    }
+
+    importedPaths := make(map[string]bool)
+    for _, p := range prog.AllPackages() {
+        importedPaths[p.Pkg.Path()] = true
+    }
+
+    cg := vta.CallGraph(ssautil.AllFunctions(prog), cha.CallGraph(prog))
+    cg.DeleteSyntheticNodes()
+    return cg, mainFn, importedPaths, nil
+}
+
+// modulePathImported reports whether any package actually compiled into the
+// program belongs to the Go module identified by coord (either the module's
+// root package itself, or one of its subpackages).
+func modulePathImported(coord string, importedPaths map[string]bool) bool {
+    p, err := parsePURL(coord)
+    if err != nil || p.Type != "golang" {
+        return false
+    }
+    modulePath := p.Name
+    if p.Namespace != "" {
+        modulePath = p.Namespace + "/" + p.Name
+    }
+    if importedPaths[modulePath] {
+        return true
+    }
+    prefix := modulePath + "/"
+    for path := range importedPaths {
+        if strings.HasPrefix(path, prefix) {
+            return true
+        }
+    }
+    return false
+}
+
+// normalizeQualifiedName strips the parens SSA puts around a method's
+// receiver type (e.g. "(pkg.Type).Method" -> "pkg.Type.Method") so it can
+// be compared against the dotted names extractAffectedSymbols scrapes from
+// free-form vulnerability text.
+func normalizeQualifiedName(s string) string {
+    return strings.NewReplacer("(", "", ")", "").Replace(s)
+}
+
+// symbolReachable walks the call graph outward from main, reporting whether
+// any reached function's qualified name is exactly one of the affected
+// symbols. Exact equality (rather than substring containment) avoids
+// false positives from unrelated symbols that merely share a suffix or
+// package prefix.
+func symbolReachable(cg *callgraph.Graph, mainFn *ssa.Function, affected []string) bool {
+    root := cg.Nodes[mainFn]
+    if root == nil {
+        return false
+    }
+    affectedSet := make(map[string]bool, len(affected))
+    for _, sym := range affected {
+        affectedSet[sym] = true
+    }
+
+    visited := make(map[*callgraph.Node]bool)
+    var walk func(n *callgraph.Node) bool
+    walk = func(n *callgraph.Node) bool {
+        if n == nil || visited[n] {
+            return false
+        }
+        visited[n] = true
+        if n.Func != nil && affectedSet[normalizeQualifiedName(n.Func.RelString(nil))] {
+            return true
+        }
+        for _, edge := range n.Out {
+            if walk(edge.Callee) {
+                return true
+            }
+        }
+        return false
+    }
+    return walk(root)
+}
+
+// AnalyzeReachability determines, for every vulnerability found in results,
+// whether any of its affected symbols are reachable from main in the
+// project at path. A vulnerability whose module has no package in the
+// compiled program is classified Required-but-unimported.
+func AnalyzeReachability(path string, results []OSSIndexResponse) ([]ReachabilityFinding, error) {
+    cg, mainFn, importedPaths, err := buildCallGraph(path)
+    if err != nil {
+        return nil, err
+    }
+
+    var findings []ReachabilityFinding
+    for _, r := range results {
+        // A dependency go.mod/go.sum names but whose package never actually
+        // appears in the compiled program's import graph can't expose any
+        // vulnerable code path at all, regardless of symbol matching.
+        imported := modulePathImported(r.Coordinates, importedPaths)
+        for _, v := range r.Vulnerabilities {
+            status := RequiredUnimported
+            if imported {
+                status = ImportedUnreachable
+                symbols := extractAffectedSymbols(v)
+                // With no extracted symbol to check, there's nothing to
+                // prove reachable; stay conservative rather than defaulting
+                // to the more alarming "Reachable" classification.
+                if len(symbols) > 0 && symbolReachable(cg, mainFn, symbols) {
+                    status = Reachable
+                }
+            }
+            findings = append(findings, ReachabilityFinding{
+                Dependency:    r.Coordinates,
+                Vulnerability: v,
+                Status:        status,
+            })
+        }
+    }
+    return findings, nil
+}
+
+// printReachabilityReport renders findings grouped into the three
+// reachability sections, most actionable first.
+func printReachabilityReport(findings []ReachabilityFinding) {
+    sections := []struct {
+        status Reachability
+        title  string
+    }{
+        {Reachable, "Reachable (exploitable via your code)"},
+        {ImportedUnreachable, "Imported but unreachable"},
+        {RequiredUnimported, "Required but unimported"},
+    }
+
+    for _, sec := range sections {
+        var grouped []ReachabilityFinding
+        for _, f := range findings {
+            if f.Status == sec.status {
+                grouped = append(grouped, f)
+            }
+        }
+        if len(grouped) == 0 {
+            continue
+        }
+        fmt.Printf("\n%s%s%s (%d)\n", Bold, sec.title, Reset, len(grouped))
+        byDep := make(map[string][]Vulnerability)
+        var order []string
+        for _, f := range grouped {
+            if _, ok := byDep[f.Dependency]; !ok {
+                order = append(order, f.Dependency)
+            }
+            byDep[f.Dependency] = append(byDep[f.Dependency], f.Vulnerability)
+        }
+        for _, dep := range order {
+            printVulnBox(dep, byDep[dep])
+        }
+    }
+}
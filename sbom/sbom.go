@@ -0,0 +1,229 @@
+// Package sbom ingests CycloneDX and SPDX software bill-of-materials
+// documents as scan input, and emits a CycloneDX VEX/BOM document as scan
+// output, so vulnscanner can slot into pipelines built around either
+// format.
+package sbom
+
+import (
+    "encoding/json"
+    "fmt"
+    "io"
+    "strings"
+)
+
+// Issue records a component the ingester could not turn into a usable PURL,
+// so callers can surface it alongside the scan report instead of silently
+// dropping it.
+type Issue struct {
+    Component string
+    Reason    string
+}
+
+type cycloneDXDoc struct {
+    BomFormat  string `json:"bomFormat"`
+    Components []struct {
+        PURL    string `json:"purl"`
+        Name    string `json:"name"`
+        Version string `json:"version"`
+    } `json:"components"`
+}
+
+type spdxDoc struct {
+    SPDXVersion string `json:"spdxVersion"`
+    Packages    []struct {
+        Name         string `json:"name"`
+        ExternalRefs []struct {
+            ReferenceCategory string `json:"referenceCategory"`
+            ReferenceType     string `json:"referenceType"`
+            ReferenceLocator  string `json:"referenceLocator"`
+        } `json:"externalRefs"`
+    } `json:"packages"`
+}
+
+// Ingest detects whether data is a CycloneDX 1.4+ or SPDX 2.3 JSON document
+// and extracts its components' PURLs. Components with no PURL, or a PURL
+// that doesn't look like one, are reported as issues rather than failing
+// the whole ingest, mirroring the filter step bomber uses on SBOM input.
+func Ingest(data []byte) (purls []string, issues []Issue, err error) {
+    var probe map[string]json.RawMessage
+    if err := json.Unmarshal(data, &probe); err != nil {
+        return nil, nil, fmt.Errorf("not a JSON SBOM document: %w", err)
+    }
+
+    switch {
+    case probe["bomFormat"] != nil:
+        return ingestCycloneDX(data)
+    case probe["spdxVersion"] != nil:
+        return ingestSPDX(data)
+    default:
+        return nil, nil, fmt.Errorf("unrecognized SBOM format: expected a \"bomFormat\" (CycloneDX) or \"spdxVersion\" (SPDX) field")
+    }
+}
+
+func ingestCycloneDX(data []byte) ([]string, []Issue, error) {
+    var doc cycloneDXDoc
+    if err := json.Unmarshal(data, &doc); err != nil {
+        return nil, nil, fmt.Errorf("parsing CycloneDX document: %w", err)
+    }
+
+    var purls []string
+    var issues []Issue
+    for _, c := range doc.Components {
+        if sanitized, ok := sanitizePURL(c.PURL); ok {
+            purls = append(purls, sanitized)
+        } else {
+            name := c.Name
+            if name == "" {
+                name = c.PURL
+            }
+            issues = append(issues, Issue{Component: name, Reason: "missing or malformed purl"})
+        }
+    }
+    return purls, issues, nil
+}
+
+func ingestSPDX(data []byte) ([]string, []Issue, error) {
+    var doc spdxDoc
+    if err := json.Unmarshal(data, &doc); err != nil {
+        return nil, nil, fmt.Errorf("parsing SPDX document: %w", err)
+    }
+
+    var purls []string
+    var issues []Issue
+    for _, pkg := range doc.Packages {
+        var purl string
+        for _, ref := range pkg.ExternalRefs {
+            if ref.ReferenceCategory == "PACKAGE-MANAGER" && ref.ReferenceType == "purl" {
+                purl = ref.ReferenceLocator
+                break
+            }
+        }
+        if sanitized, ok := sanitizePURL(purl); ok {
+            purls = append(purls, sanitized)
+        } else {
+            issues = append(issues, Issue{Component: pkg.Name, Reason: "missing or malformed purl externalRef"})
+        }
+    }
+    return purls, issues, nil
+}
+
+// sanitizePURL rejects coordinates that don't look like a minimally valid
+// "pkg:<type>/<path>@<version>" package URL.
+func sanitizePURL(purl string) (string, bool) {
+    rest, ok := strings.CutPrefix(purl, "pkg:")
+    if !ok {
+        return "", false
+    }
+    typ, path, ok := strings.Cut(rest, "/")
+    if !ok || typ == "" {
+        return "", false
+    }
+    name, version, ok := strings.Cut(path, "@")
+    if !ok || name == "" || version == "" {
+        return "", false
+    }
+    return purl, true
+}
+
+// Component is a scanned dependency, identified by its PURL, to be listed
+// in an emitted BOM.
+type Component struct {
+    PURL string
+}
+
+// Rating is one scoring of a vulnerability's severity.
+type Rating struct {
+    Score    float64
+    Severity string
+    Method   string
+}
+
+// Vulnerability is a single finding to include in an emitted VEX/BOM
+// document.
+type Vulnerability struct {
+    ID            string
+    Description   string
+    Ratings       []Rating
+    CWEs          []int
+    Advisories    []string
+    AnalysisState string // e.g. "exploitable", "not_affected", "resolved"
+    AffectsPURL   string
+}
+
+type cyclonedxOutput struct {
+    BomFormat    string                  `json:"bomFormat"`
+    SpecVersion  string                  `json:"specVersion"`
+    Version      int                     `json:"version"`
+    Components   []cyclonedxComponent    `json:"components"`
+    Vulnerabilities []cyclonedxVulnerability `json:"vulnerabilities,omitempty"`
+}
+
+type cyclonedxComponent struct {
+    Type string `json:"type"`
+    PURL string `json:"purl"`
+}
+
+type cyclonedxRating struct {
+    Score    float64 `json:"score"`
+    Severity string  `json:"severity"`
+    Method   string  `json:"method,omitempty"`
+}
+
+type cyclonedxAdvisory struct {
+    URL string `json:"url"`
+}
+
+type cyclonedxAnalysis struct {
+    State string `json:"state"`
+}
+
+type cyclonedxAffects struct {
+    Ref string `json:"ref"`
+}
+
+type cyclonedxVulnerability struct {
+    ID          string              `json:"id"`
+    Description string              `json:"description,omitempty"`
+    Ratings     []cyclonedxRating   `json:"ratings,omitempty"`
+    CWEs        []int               `json:"cwes,omitempty"`
+    Advisories  []cyclonedxAdvisory `json:"advisories,omitempty"`
+    Analysis    *cyclonedxAnalysis  `json:"analysis,omitempty"`
+    Affects     []cyclonedxAffects  `json:"affects,omitempty"`
+}
+
+// EmitCycloneDX writes a CycloneDX 1.4 BOM document listing components and
+// their discovered vulnerabilities (a VEX view) to w.
+func EmitCycloneDX(w io.Writer, components []Component, vulns []Vulnerability) error {
+    out := cyclonedxOutput{
+        BomFormat:   "CycloneDX",
+        SpecVersion: "1.4",
+        Version:     1,
+    }
+    for _, c := range components {
+        out.Components = append(out.Components, cyclonedxComponent{Type: "library", PURL: c.PURL})
+    }
+    for _, v := range vulns {
+        cv := cyclonedxVulnerability{
+            ID:          v.ID,
+            Description: v.Description,
+            CWEs:        v.CWEs,
+        }
+        for _, r := range v.Ratings {
+            cv.Ratings = append(cv.Ratings, cyclonedxRating{Score: r.Score, Severity: r.Severity, Method: r.Method})
+        }
+        for _, a := range v.Advisories {
+            cv.Advisories = append(cv.Advisories, cyclonedxAdvisory{URL: a})
+        }
+        if v.AnalysisState != "" {
+            cv.Analysis = &cyclonedxAnalysis{State: v.AnalysisState}
+        }
+        if v.AffectsPURL != "" {
+            cv.Affects = []cyclonedxAffects{{Ref: v.AffectsPURL}}
+        }
+        out.Vulnerabilities = append(out.Vulnerabilities, cv)
+    }
+
+    enc := json.NewEncoder(w)
+    enc.SetIndent("", "  ")
+    return enc.Encode(out)
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+    "encoding/json"
+    "fmt"
+    "io/ioutil"
+    "os"
+    "strings"
+
+    "github.com/rohankumardubey/vulnscanner/sbom"
+)
+
+// severityLabel maps a CVSS score to the text severity labels CycloneDX
+// ratings expect.
+func severityLabel(score float64) string {
+    switch {
+    case score >= 9:
+        return "critical"
+    case score >= 7:
+        return "high"
+    case score >= 4:
+        return "medium"
+    default:
+        return "low"
+    }
+}
+
+// runSBOMCommand implements `vulnscanner sbom <file>`: it ingests a
+// CycloneDX or SPDX document as the dependency list, queries source(s) for
+// vulnerabilities, and renders the report in the requested --output format.
+func runSBOMCommand(path string, sourceFlag string, output string, ossCfg OSSIndexConfig) {
+    data, err := ioutil.ReadFile(path)
+    if err != nil {
+        fmt.Printf("Error reading SBOM file: %v\n", err)
+        os.Exit(1)
+    }
+
+    pkgs, issues, err := sbom.Ingest(data)
+    if err != nil {
+        fmt.Printf("Error ingesting SBOM: %v\n", err)
+        os.Exit(1)
+    }
+    for _, issue := range issues {
+        fmt.Printf("%sSkipping component %q: %s%s\n", Yellow, issue.Component, issue.Reason, Reset)
+    }
+    if len(pkgs) == 0 {
+        fmt.Println("No usable components found in SBOM.")
+        os.Exit(0)
+    }
+
+    sources, err := sourcesForFlag(sourceFlag, ossCfg)
+    if err != nil {
+        fmt.Printf("Error: %v\n", err)
+        os.Exit(1)
+    }
+    resultSets := make([][]OSSIndexResponse, 0, len(sources))
+    for _, src := range sources {
+        r, err := src.Query(pkgs)
+        if err != nil {
+            fmt.Printf("Error querying vulnerabilities: %v\n", err)
+            os.Exit(1)
+        }
+        resultSets = append(resultSets, r)
+    }
+    results := mergeResults(resultSets...)
+
+    switch strings.ToLower(output) {
+    case "", "text":
+        printReport(results)
+    case "json":
+        enc := json.NewEncoder(os.Stdout)
+        enc.SetIndent("", "  ")
+        if err := enc.Encode(results); err != nil {
+            fmt.Printf("Error encoding JSON report: %v\n", err)
+            os.Exit(1)
+        }
+    case "cyclonedx":
+        components := make([]sbom.Component, len(pkgs))
+        for i, p := range pkgs {
+            components[i] = sbom.Component{PURL: p}
+        }
+        var vulns []sbom.Vulnerability
+        for _, r := range results {
+            for _, v := range r.Vulnerabilities {
+                vulns = append(vulns, sbom.Vulnerability{
+                    ID:          firstNonEmpty(v.CVE, v.ID),
+                    Description: v.Description,
+                    Ratings:     []sbom.Rating{{Score: v.CVSSScore, Severity: severityLabel(v.CVSSScore), Method: "CVSSv3"}},
+                    Advisories:  []string{v.Reference},
+                    AffectsPURL: r.Coordinates,
+                })
+            }
+        }
+        if err := sbom.EmitCycloneDX(os.Stdout, components, vulns); err != nil {
+            fmt.Printf("Error emitting CycloneDX BOM: %v\n", err)
+            os.Exit(1)
+        }
+    case "spdx":
+        fmt.Println("Error: --output=spdx emission is not yet supported; use --output=cyclonedx for a machine-readable report.")
+        os.Exit(1)
+    default:
+        fmt.Printf("Unknown --output value %q (want cyclonedx, spdx, text, or json)\n", output)
+        os.Exit(1)
+    }
+}
+
+func firstNonEmpty(vals ...string) string {
+    for _, v := range vals {
+        if v != "" {
+            return v
+        }
+    }
+    return ""
+}
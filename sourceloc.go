@@ -0,0 +1,23 @@
+package main
+
+// SourceLocation is where a dependency coordinate was found in its
+// manifest, so machine-readable reports (SARIF in particular) can point a
+// reviewer straight at the offending line.
+type SourceLocation struct {
+    File string
+    Line int
+}
+
+// sourceLocations is populated as each Parser reads a manifest and
+// consulted later when rendering a report; it's written and read from a
+// single goroutine per run, so it needs no locking.
+var sourceLocations = map[string]SourceLocation{}
+
+func recordLocation(coord, file string, line int) {
+    sourceLocations[coord] = SourceLocation{File: file, Line: line}
+}
+
+func lookupLocation(coord string) (SourceLocation, bool) {
+    loc, ok := sourceLocations[coord]
+    return loc, ok
+}
@@ -0,0 +1,89 @@
+package main
+
+import "strings"
+
+// VulnSource is a backend that can be queried for known vulnerabilities
+// affecting a set of package URLs. OSSIndexSource and OSVSource both
+// implement it so the scanner can use either, or both, interchangeably.
+type VulnSource interface {
+    Query(coords []string) ([]OSSIndexResponse, error)
+}
+
+// OSSIndexSource queries Sonatype OSS Index, the scanner's original backend.
+type OSSIndexSource struct {
+    Config OSSIndexConfig
+}
+
+func (s OSSIndexSource) Query(coords []string) ([]OSSIndexResponse, error) {
+    return checkVulnerabilitiesWithConfig(coords, s.Config)
+}
+
+// resultKey identifies a vulnerability within a dependency's findings for
+// deduplication purposes: prefer a CVE alias when one is present, since the
+// same CVE is often reported under different IDs by different sources.
+func resultKey(v Vulnerability) string {
+    if v.CVE != "" {
+        return v.CVE
+    }
+    return v.ID
+}
+
+// mergeResults combines the per-dependency results of multiple sources,
+// deduping vulnerabilities that share a coordinate and a CVE/alias.
+func mergeResults(sets ...[]OSSIndexResponse) []OSSIndexResponse {
+    byCoord := make(map[string]*OSSIndexResponse)
+    var order []string
+
+    for _, set := range sets {
+        for _, r := range set {
+            existing, ok := byCoord[r.Coordinates]
+            if !ok {
+                order = append(order, r.Coordinates)
+                copyOfR := OSSIndexResponse{Coordinates: r.Coordinates}
+                existing = &copyOfR
+                byCoord[r.Coordinates] = existing
+            }
+
+            seen := make(map[string]bool)
+            for _, v := range existing.Vulnerabilities {
+                seen[resultKey(v)] = true
+            }
+            for _, v := range r.Vulnerabilities {
+                key := resultKey(v)
+                if key != "" && seen[key] {
+                    continue
+                }
+                seen[key] = true
+                existing.Vulnerabilities = append(existing.Vulnerabilities, v)
+            }
+        }
+    }
+
+    results := make([]OSSIndexResponse, 0, len(order))
+    for _, coord := range order {
+        results = append(results, *byCoord[coord])
+    }
+    return results
+}
+
+// sourcesForFlag resolves the --source flag value into the VulnSource(s)
+// the scanner should query, threading ossCfg through to every OSS Index
+// source so they all honor the same concurrency/cache/auth settings.
+func sourcesForFlag(name string, ossCfg OSSIndexConfig) ([]VulnSource, error) {
+    switch strings.ToLower(name) {
+    case "", "ossindex":
+        return []VulnSource{OSSIndexSource{Config: ossCfg}}, nil
+    case "osv":
+        return []VulnSource{OSVSource{}}, nil
+    case "both":
+        return []VulnSource{OSSIndexSource{Config: ossCfg}, OSVSource{}}, nil
+    default:
+        return nil, &unknownSourceError{name: name}
+    }
+}
+
+type unknownSourceError struct{ name string }
+
+func (e *unknownSourceError) Error() string {
+    return "unknown vulnerability source: " + e.name + " (want ossindex, osv, or both)"
+}